@@ -0,0 +1,58 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+)
+
+// runDiff implements "scout diff --since <duration>": it reports SKUs
+// whose price changed between the earliest snapshot taken at or after
+// (now - since) and the latest known snapshot.
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	dbDSN := fs.String("db", "scout.db", "Database to read SKU snapshots from (sqlite path, or a postgres:// DSN)")
+	since := fs.Duration("since", 24*time.Hour, "Report price changes since this long ago")
+	fs.Parse(args)
+
+	store, err := openStore(*dbDSN)
+	if err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+	defer store.Close()
+
+	changes, err := store.Diff(context.Background(), *since)
+	if err != nil {
+		log.Fatalf("ERROR: Cannot compute price diff: %v", err)
+	}
+
+	if len(changes) == 0 {
+		fmt.Printf("No price changes in the last %s\n", *since)
+		return
+	}
+
+	for _, c := range changes {
+		fmt.Printf("%s [%s/%s]: %.10f -> %.10f (%s -> %s)\n",
+			c.SkuId, c.Provider, c.Region, c.OldPrice, c.NewPrice,
+			c.OldFetched.Format("2006-01-02 15:04:05"), c.NewFetched.Format("2006-01-02 15:04:05"))
+	}
+}
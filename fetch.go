@@ -0,0 +1,160 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/thecloudranger/GC-SKU-Scout-Go/pricing"
+	"github.com/thecloudranger/GC-SKU-Scout-Go/storage"
+)
+
+// repeatableFlag collects every value passed to a repeatable flag like
+// --filter, which flag.FlagSet doesn't support natively.
+type repeatableFlag []string
+
+func (r *repeatableFlag) String() string { return strings.Join(*r, ",") }
+
+func (r *repeatableFlag) Set(value string) error {
+	*r = append(*r, value)
+	return nil
+}
+
+// runFetch implements "scout fetch": it fetches the current catalog for
+// a provider/region, writes a timestamped JSON dump for compatibility
+// with older tooling, and persists the snapshot to the price history
+// database so "scout diff" and "scout history" have something to read.
+func runFetch(args []string) {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	region := fs.String("region", "me-central2", "Cloud region")
+	providerName := fs.String("provider", "gcp", "Pricing provider to use: gcp or aws")
+	awsService := fs.String("aws-service", "AmazonEC2", "AWS service code to price (only used with --provider=aws)")
+	dbDSN := fs.String("db", "scout.db", "Database to persist SKU snapshots to (sqlite path, or a postgres:// DSN)")
+	refreshSpot := fs.Duration("refresh-spot", 0, "If set, re-poll spot/preemptible SKUs at this interval and rewrite the output file (AWS re-polls spot prices only; GCP has no spot-only endpoint, so it re-scans the full catalog each tick)")
+	concurrency := fs.Int("concurrency", pricing.DefaultGCPConcurrency, "Number of Cloud Billing Catalog services to fetch concurrently (gcp provider only)")
+	qps := fs.Float64("qps", pricing.DefaultGCPQPS, "Starting request rate to the Cloud Billing Catalog API, adapted on 429s (gcp provider only)")
+	var filterExprs repeatableFlag
+	fs.Var(&filterExprs, "filter", "Client-side SKU filter, repeatable (e.g. Field=ResourceFamily,Type=TERM_MATCH,Value=Compute)")
+	fs.Parse(args)
+
+	filters := make([]pricing.Filter, 0, len(filterExprs))
+	for _, expr := range filterExprs {
+		f, err := pricing.ParseFilter(expr)
+		if err != nil {
+			log.Fatalf("ERROR: %v", err)
+		}
+		filters = append(filters, f)
+	}
+
+	provider, err := newProvider(*providerName, *region, *awsService, gcpFetchOptions{Concurrency: *concurrency, QPS: *qps})
+	if err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+
+	store, err := openStore(*dbDSN)
+	if err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+	defer store.Close()
+
+	fmt.Printf("Fetching pricing for region: %s\n", *region)
+
+	ctx := context.Background()
+	allSkus, err := provider.FetchSKUs(ctx, *region)
+	if err != nil {
+		log.Fatalf("ERROR: Cannot fetch SKUs: %v", err)
+	}
+	allSkus = pricing.ApplyFilters(allSkus, filters)
+	fetchedAt := time.Now()
+
+	if err := store.Save(ctx, *providerName, *region, allSkus, fetchedAt); err != nil {
+		log.Fatalf("ERROR: Cannot save snapshot to database: %v", err)
+	}
+
+	filename := fmt.Sprintf("pricing-%s-%s-%s.json", *providerName, *region, fetchedAt.Format("2006-01-02-15-04-05"))
+	if err := writeSkus(filename, allSkus); err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+	fmt.Printf("\nPricing information saved to %s and to %s\n", filename, *dbDSN)
+	fmt.Printf("\nFound %d SKUs for region %s\n", len(allSkus), *region)
+
+	if *refreshSpot > 0 {
+		spotProvider, ok := provider.(pricing.SpotProvider)
+		if !ok {
+			log.Fatalf("ERROR: provider %q does not support --refresh-spot", *providerName)
+		}
+		refreshSpotLoop(ctx, spotProvider, store, *providerName, *region, allSkus, filename, *refreshSpot)
+	}
+}
+
+// refreshSpotLoop re-polls spot/preemptible SKUs on the given interval,
+// rewriting filename with the non-spot entries of allSkus plus the
+// fresh spot entries, and appending a snapshot of the spot SKUs to
+// store each time. The caller's allSkus slice is not modified. The
+// non-spot entries are those of the initial fetch and are not updated;
+// how much each poll fetches depends on the provider's FetchSpotSKUs
+// (the GCP provider re-scans its full catalog on every poll).
+func refreshSpotLoop(ctx context.Context, spotProvider pricing.SpotProvider, store *storage.Store, providerName, region string, allSkus []pricing.Sku, filename string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	nonSpot := make([]pricing.Sku, 0, len(allSkus))
+	for _, sku := range allSkus {
+		if !sku.IsSpot {
+			nonSpot = append(nonSpot, sku)
+		}
+	}
+
+	for range ticker.C {
+		spotSkus, err := spotProvider.FetchSpotSKUs(ctx, region)
+		if err != nil {
+			log.Printf("WARN: Cannot refresh spot SKUs: %v", err)
+			continue
+		}
+
+		allSkus = append(append([]pricing.Sku{}, nonSpot...), spotSkus...)
+		if err := writeSkus(filename, allSkus); err != nil {
+			log.Printf("WARN: Cannot write refreshed spot SKUs: %v", err)
+			continue
+		}
+		if err := store.Save(ctx, providerName, region, spotSkus, time.Now()); err != nil {
+			log.Printf("WARN: Cannot save refreshed spot SKUs to database: %v", err)
+			continue
+		}
+		fmt.Printf("\nRefreshed %d spot SKUs in %s\n", len(spotSkus), filename)
+	}
+}
+
+// writeSkus marshals skus to indented JSON and writes it to filename.
+func writeSkus(filename string, skus []pricing.Sku) error {
+	jsonData, err := json.MarshalIndent(skus, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal to JSON: %w", err)
+	}
+	if err := os.WriteFile(filename, jsonData, 0644); err != nil {
+		return fmt.Errorf("cannot write to file: %w", err)
+	}
+	return nil
+}
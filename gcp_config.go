@@ -0,0 +1,30 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "gopkg.in/yaml.v3"
+
+// GcpConfig mirrors gcp.yml, which lists the GCP regions this tool is
+// allowed to fetch pricing for.
+type GcpConfig struct {
+	Region map[string]interface{} `yaml:"region"`
+}
+
+func unmarshalGcpConfig(data []byte, out *GcpConfig) error {
+	return yaml.Unmarshal(data, out)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+)
+
+// runHistory implements "scout history <sku_id>": it prints every
+// recorded price observation for the given SKU, oldest first.
+func runHistory(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	dbDSN := fs.String("db", "scout.db", "Database to read SKU snapshots from (sqlite path, or a postgres:// DSN)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf("usage: scout history [--db <dsn>] <sku_id>")
+	}
+	skuID := fs.Arg(0)
+
+	store, err := openStore(*dbDSN)
+	if err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+	defer store.Close()
+
+	points, err := store.History(context.Background(), skuID)
+	if err != nil {
+		log.Fatalf("ERROR: Cannot fetch price history for %s: %v", skuID, err)
+	}
+
+	if len(points) == 0 {
+		fmt.Printf("No price history recorded for %s\n", skuID)
+		return
+	}
+
+	for _, p := range points {
+		fmt.Printf("%s  %.10f %s\n", p.FetchedAt.Format("2006-01-02 15:04:05"), p.Price, p.CurrencyCode)
+	}
+}
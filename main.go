@@ -0,0 +1,92 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command scout fetches cloud SKU pricing and tracks how it changes
+// over time. See "scout -h" and the per-subcommand -h output for usage.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/thecloudranger/GC-SKU-Scout-Go/pricing"
+	"github.com/thecloudranger/GC-SKU-Scout-Go/storage"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatalf("usage: scout <fetch|diff|history|price-machine> [flags]")
+	}
+
+	args := os.Args[2:]
+	switch os.Args[1] {
+	case "fetch":
+		runFetch(args)
+	case "diff":
+		runDiff(args)
+	case "history":
+		runHistory(args)
+	case "price-machine":
+		runPriceMachine(args)
+	default:
+		log.Fatalf("ERROR: unknown subcommand %q (want fetch, diff, history, or price-machine)", os.Args[1])
+	}
+}
+
+// gcpFetchOptions tunes the concurrency of the GCP provider's catalog
+// fetch; a zero value falls back to pricing's own defaults.
+type gcpFetchOptions struct {
+	Concurrency int
+	QPS         float64
+}
+
+func newProvider(providerName, region, awsService string, gcpOpts gcpFetchOptions) (pricing.Provider, error) {
+	switch providerName {
+	case "gcp":
+		apiKey := os.Getenv("API_KEY")
+		if apiKey == "" {
+			return nil, fmt.Errorf("API_KEY environment variable not set")
+		}
+
+		gcpFile, err := os.ReadFile("gcp.yml")
+		if err != nil {
+			return nil, fmt.Errorf("cannot read gcp.yml: %w", err)
+		}
+		var gcpConfig GcpConfig
+		if err := unmarshalGcpConfig(gcpFile, &gcpConfig); err != nil {
+			return nil, fmt.Errorf("cannot unmarshal gcp.yml: %w", err)
+		}
+		if _, ok := gcpConfig.Region[region]; !ok {
+			return nil, fmt.Errorf("region '%s' not found in gcp.yml", region)
+		}
+
+		return pricing.NewGCPProvider(apiKey, gcpOpts.Concurrency, gcpOpts.QPS), nil
+	case "aws":
+		return pricing.NewAWSProvider(awsService), nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q (want gcp or aws)", providerName)
+	}
+}
+
+func openStore(dsn string) (*storage.Store, error) {
+	store, err := storage.OpenFromDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open database %q: %w", dsn, err)
+	}
+	return store, nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mapper resolves a GCE machine type (e.g. "n2-standard-8") to
+// the CPU and RAM SKUs that price it, and computes the resulting
+// effective hourly price.
+package mapper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MachineSpec is the vCPU/memory shape implied by a predefined GCE
+// machine type name.
+type MachineSpec struct {
+	Family    string // e.g. "n2"
+	Tier      string // "standard", "highmem", or "highcpu"
+	VCPUs     int
+	MemoryGiB float64
+}
+
+// memoryGiBPerVCPU mirrors the fixed memory-per-vCPU ratios Compute
+// Engine uses for each predefined machine family and tier.
+var memoryGiBPerVCPU = map[string]map[string]float64{
+	"n2":  {"standard": 4, "highmem": 8, "highcpu": 1},
+	"n2d": {"standard": 4, "highmem": 8, "highcpu": 1},
+	"n1":  {"standard": 3.75, "highmem": 6.5, "highcpu": 0.9},
+	"e2":  {"standard": 4, "highmem": 8, "highcpu": 1},
+	"c2":  {"standard": 4},
+	"t2d": {"standard": 4},
+}
+
+// ParseMachineType parses a predefined machine type name of the form
+// "<family>-<tier>-<vcpus>", e.g. "n2-standard-8".
+func ParseMachineType(machineType string) (MachineSpec, error) {
+	parts := strings.Split(machineType, "-")
+	if len(parts) != 3 {
+		return MachineSpec{}, fmt.Errorf("unsupported machine type %q: want <family>-<tier>-<vcpus>", machineType)
+	}
+	family, tier, vcpuStr := parts[0], parts[1], parts[2]
+
+	vcpus, err := strconv.Atoi(vcpuStr)
+	if err != nil {
+		return MachineSpec{}, fmt.Errorf("unsupported machine type %q: invalid vCPU count %q", machineType, vcpuStr)
+	}
+
+	ratios, ok := memoryGiBPerVCPU[family]
+	if !ok {
+		return MachineSpec{}, fmt.Errorf("unsupported machine family %q", family)
+	}
+	ratio, ok := ratios[tier]
+	if !ok {
+		return MachineSpec{}, fmt.Errorf("unsupported tier %q for machine family %q", tier, family)
+	}
+
+	return MachineSpec{
+		Family:    family,
+		Tier:      tier,
+		VCPUs:     vcpus,
+		MemoryGiB: float64(vcpus) * ratio,
+	}, nil
+}
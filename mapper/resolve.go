@@ -0,0 +1,167 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mapper
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thecloudranger/GC-SKU-Scout-Go/pricing"
+)
+
+// familyDisplayNames maps a machine family to the name Cloud Billing
+// Catalog SKU descriptions use for it, e.g. "n2" -> "N2" in
+// "N2 Instance Core running in ...".
+var familyDisplayNames = map[string]string{
+	"n1":  "N1",
+	"n2":  "N2",
+	"n2d": "N2D",
+	"e2":  "E2",
+	"c2":  "C2",
+	"t2d": "T2D",
+}
+
+const localSSDGiBPerDisk = 375
+
+// localSSDHoursPerMonth is GCP's standard average-hours-per-month
+// constant, used to convert the local SSD SKU's per-GiB-month price
+// (its usual billing unit) down to a per-GiB-hour price.
+const localSSDHoursPerMonth = 730
+
+// localSSDHourlyUnitPrice returns sku's price in USD per GiB-hour,
+// converting from whatever UsageUnit the Cloud Billing Catalog
+// reports it in. Local SSD is normally billed per GiB-month; an
+// unrecognized unit is refused rather than silently mixing units into
+// the effective hourly total.
+func localSSDHourlyUnitPrice(sku pricing.Sku) (float64, error) {
+	switch sku.UsageUnit {
+	case "GiBy.mo":
+		return sku.CalculatedPrice / localSSDHoursPerMonth, nil
+	case "GiBy.h", "GiBy":
+		return sku.CalculatedPrice, nil
+	default:
+		return 0, fmt.Errorf("unrecognized local SSD usage unit %q: cannot normalize to a per-hour price", sku.UsageUnit)
+	}
+}
+
+// Resolution is the per-machine-type price breakdown: which SKUs were
+// matched and how much each contributes to the effective hourly price.
+type Resolution struct {
+	MachineType         string
+	Spec                MachineSpec
+	CPUSku              pricing.Sku
+	RAMSku              pricing.Sku
+	LocalSSDSku         pricing.Sku
+	LocalSSDCount       int
+	CPUHourlyPrice      float64
+	RAMHourlyPrice      float64
+	LocalSSDHourlyPrice float64
+	EffectiveHourly     float64
+}
+
+// findComputeSku returns the single Compute Engine SKU in skus whose
+// description matches "<family> instance <resourceKind>" for the given
+// usage type (e.g. resourceKind "core" or "ram", usageType "OnDemand"
+// or "Preemptible"). Matching on description text is best-effort: the
+// Cloud Billing Catalog doesn't expose a more structured field for it.
+func findComputeSku(skus []pricing.Sku, family, usageType, resourceKind string) (pricing.Sku, error) {
+	displayName, ok := familyDisplayNames[family]
+	if !ok {
+		return pricing.Sku{}, fmt.Errorf("no SKU description mapping for machine family %q", family)
+	}
+	needle := strings.ToLower(displayName + " instance " + resourceKind)
+
+	for _, sku := range skus {
+		if sku.ResourceFamily != "Compute" || sku.UsageType != usageType {
+			continue
+		}
+		if strings.Contains(strings.ToLower(sku.Description), needle) {
+			return sku, nil
+		}
+	}
+	return pricing.Sku{}, fmt.Errorf("no %s SKU found for machine family %q (usage type %s)", resourceKind, family, usageType)
+}
+
+// findLocalSSDSku returns the region's local SSD SKU for the given
+// usage type.
+func findLocalSSDSku(skus []pricing.Sku, usageType string) (pricing.Sku, error) {
+	for _, sku := range skus {
+		if sku.ResourceFamily != "Storage" || sku.UsageType != usageType {
+			continue
+		}
+		if strings.Contains(strings.ToLower(sku.Description), "ssd backed local storage") {
+			return sku, nil
+		}
+	}
+	return pricing.Sku{}, fmt.Errorf("no local SSD SKU found (usage type %s)", usageType)
+}
+
+// ResolveMachinePrice resolves the CPU, RAM, and (if localSSDCount > 0)
+// local SSD SKUs for machineType within skus, and computes the
+// resulting effective hourly price. skus must already be scoped to the
+// target region. The matched CPU/RAM/local-SSD SKUs have their Mapping
+// field set to machineType.
+func ResolveMachinePrice(skus []pricing.Sku, machineType string, preemptible bool, localSSDCount int) (Resolution, error) {
+	spec, err := ParseMachineType(machineType)
+	if err != nil {
+		return Resolution{}, err
+	}
+
+	usageType := "OnDemand"
+	if preemptible {
+		usageType = "Preemptible"
+	}
+
+	cpuSku, err := findComputeSku(skus, spec.Family, usageType, "core")
+	if err != nil {
+		return Resolution{}, err
+	}
+	ramSku, err := findComputeSku(skus, spec.Family, usageType, "ram")
+	if err != nil {
+		return Resolution{}, err
+	}
+	cpuSku.Mapping = machineType
+	ramSku.Mapping = machineType
+
+	res := Resolution{
+		MachineType:    machineType,
+		Spec:           spec,
+		CPUSku:         cpuSku,
+		RAMSku:         ramSku,
+		LocalSSDCount:  localSSDCount,
+		CPUHourlyPrice: float64(spec.VCPUs) * cpuSku.CalculatedPrice,
+		RAMHourlyPrice: spec.MemoryGiB * ramSku.CalculatedPrice,
+	}
+
+	if localSSDCount > 0 {
+		ssdSku, err := findLocalSSDSku(skus, usageType)
+		if err != nil {
+			return Resolution{}, err
+		}
+		ssdSku.Mapping = machineType
+		perGiBHour, err := localSSDHourlyUnitPrice(ssdSku)
+		if err != nil {
+			return Resolution{}, fmt.Errorf("cannot price local SSD for %q: %w", machineType, err)
+		}
+		res.LocalSSDSku = ssdSku
+		res.LocalSSDHourlyPrice = float64(localSSDCount) * localSSDGiBPerDisk * perGiBHour
+	}
+
+	res.EffectiveHourly = res.CPUHourlyPrice + res.RAMHourlyPrice + res.LocalSSDHourlyPrice
+	return res, nil
+}
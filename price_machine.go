@@ -0,0 +1,66 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/thecloudranger/GC-SKU-Scout-Go/mapper"
+)
+
+// runPriceMachine implements "scout price-machine": it resolves a GCE
+// machine type to its CPU and RAM (and optionally local SSD) SKUs and
+// prints the resulting effective hourly price, split by component.
+func runPriceMachine(args []string) {
+	fs := flag.NewFlagSet("price-machine", flag.ExitOnError)
+	machineType := fs.String("type", "", "GCE machine type, e.g. n2-standard-8 (required)")
+	region := fs.String("region", "me-central2", "Cloud region")
+	preemptible := fs.Bool("preemptible", false, "Price the Spot/preemptible SKUs instead of on-demand")
+	localSSDCount := fs.Int("local-ssd-count", 0, "Number of 375 GiB local SSD partitions to include in the price")
+	fs.Parse(args)
+
+	if *machineType == "" {
+		log.Fatalf("usage: scout price-machine --type <machine-type> [--region <region>] [--preemptible] [--local-ssd-count <n>]")
+	}
+
+	provider, err := newProvider("gcp", *region, "", gcpFetchOptions{})
+	if err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+
+	skus, err := provider.FetchSKUs(context.Background(), *region)
+	if err != nil {
+		log.Fatalf("ERROR: Cannot fetch SKUs: %v", err)
+	}
+
+	res, err := mapper.ResolveMachinePrice(skus, *machineType, *preemptible, *localSSDCount)
+	if err != nil {
+		log.Fatalf("ERROR: Cannot resolve price for %s: %v", *machineType, err)
+	}
+
+	fmt.Printf("%s in %s (%d vCPUs, %.2f GiB RAM)\n", res.MachineType, *region, res.Spec.VCPUs, res.Spec.MemoryGiB)
+	fmt.Printf("  CPU:       %s -> $%.6f/hr\n", res.CPUSku.SkuId, res.CPUHourlyPrice)
+	fmt.Printf("  RAM:       %s -> $%.6f/hr\n", res.RAMSku.SkuId, res.RAMHourlyPrice)
+	if res.LocalSSDCount > 0 {
+		fmt.Printf("  Local SSD: %s -> $%.6f/hr (%d x 375 GiB)\n", res.LocalSSDSku.SkuId, res.LocalSSDHourlyPrice, res.LocalSSDCount)
+	}
+	fmt.Printf("  Effective: $%.6f/hr\n", res.EffectiveHourly)
+}
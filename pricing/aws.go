@@ -0,0 +1,171 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awspricing "github.com/aws/aws-sdk-go-v2/service/pricing"
+	"github.com/aws/aws-sdk-go-v2/service/pricing/types"
+)
+
+// awsRegionLongNames maps AWS region codes to the "location" values the
+// Pricing API expects, e.g. "eu-west-1" -> "EU (Ireland)". The Pricing
+// API only runs in us-east-1 regardless of the region being priced.
+var awsRegionLongNames = map[string]string{
+	"us-east-1":      "US East (N. Virginia)",
+	"us-east-2":      "US East (Ohio)",
+	"us-west-1":      "US West (N. California)",
+	"us-west-2":      "US West (Oregon)",
+	"eu-west-1":      "EU (Ireland)",
+	"eu-west-2":      "EU (London)",
+	"eu-west-3":      "EU (Paris)",
+	"eu-central-1":   "EU (Frankfurt)",
+	"eu-north-1":     "EU (Stockholm)",
+	"ap-south-1":     "Asia Pacific (Mumbai)",
+	"ap-southeast-1": "Asia Pacific (Singapore)",
+	"ap-southeast-2": "Asia Pacific (Sydney)",
+	"ap-northeast-1": "Asia Pacific (Tokyo)",
+	"ap-northeast-2": "Asia Pacific (Seoul)",
+	"sa-east-1":      "South America (Sao Paulo)",
+	"ca-central-1":   "Canada (Central)",
+	"me-central-1":   "Middle East (UAE)",
+}
+
+// AWSProvider fetches SKUs from the AWS Price List Query API
+// (pricing:GetProducts). The API is only served out of us-east-1, so
+// the client is pinned there and the target region is instead passed
+// as a "location" filter.
+type AWSProvider struct {
+	ServiceCode string // e.g. "AmazonEC2"
+}
+
+// NewAWSProvider returns a Provider for the given AWS service code.
+func NewAWSProvider(serviceCode string) *AWSProvider {
+	return &AWSProvider{ServiceCode: serviceCode}
+}
+
+// awsProduct is the subset of the GetProducts price-list JSON document
+// we care about. AWS returns the full document as an opaque JSON
+// string per product, so we only decode the fields used to build a Sku.
+type awsProduct struct {
+	Product struct {
+		SKU        string `json:"sku"`
+		Attributes struct {
+			InstanceType    string `json:"instanceType"`
+			Location        string `json:"location"`
+			UsageType       string `json:"usagetype"`
+			OperatingSystem string `json:"operatingSystem"`
+			Tenancy         string `json:"tenancy"`
+		} `json:"attributes"`
+	} `json:"product"`
+	Terms struct {
+		OnDemand map[string]struct {
+			PriceDimensions map[string]struct {
+				Description  string            `json:"description"`
+				Unit         string            `json:"unit"`
+				PricePerUnit map[string]string `json:"pricePerUnit"`
+			} `json:"priceDimensions"`
+		} `json:"OnDemand"`
+	} `json:"terms"`
+}
+
+// FetchSKUs queries GetProducts for the provider's service, filtered to
+// the given region, and normalizes each OnDemand price dimension into a
+// Sku.
+func (p *AWSProvider) FetchSKUs(ctx context.Context, region string) ([]Sku, error) {
+	location, ok := awsRegionLongNames[region]
+	if !ok {
+		return nil, fmt.Errorf("no location mapping for AWS region %q", region)
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion("us-east-1"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot load AWS config: %w", err)
+	}
+	client := awspricing.NewFromConfig(cfg)
+
+	req := &awspricing.GetProductsInput{
+		ServiceCode: aws.String(p.ServiceCode),
+		Filters: []types.Filter{
+			{Type: types.FilterTypeTermMatch, Field: aws.String("capacitystatus"), Value: aws.String("Used")},
+			{Type: types.FilterTypeTermMatch, Field: aws.String("preInstalledSw"), Value: aws.String("NA")},
+			{Type: types.FilterTypeTermMatch, Field: aws.String("location"), Value: aws.String(location)},
+		},
+	}
+
+	var allSkus []Sku
+	paginator := awspricing.NewGetProductsPaginator(client, req)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("GetProducts: %w", err)
+		}
+
+		for _, raw := range page.PriceList {
+			var product awsProduct
+			if err := json.Unmarshal([]byte(raw), &product); err != nil {
+				continue
+			}
+
+			for _, term := range product.Terms.OnDemand {
+				for _, dimension := range term.PriceDimensions {
+					priceStr, ok := dimension.PricePerUnit["USD"]
+					if !ok {
+						continue
+					}
+					calculatedPrice, err := strconv.ParseFloat(priceStr, 64)
+					if err != nil {
+						continue
+					}
+
+					allSkus = append(allSkus, Sku{
+						SkuId:                product.Product.SKU,
+						Description:          dimension.Description,
+						ServiceDisplayName:   p.ServiceCode,
+						ResourceFamily:       product.Product.Attributes.InstanceType,
+						ResourceGroup:        product.Product.Attributes.Tenancy,
+						UsageType:            product.Product.Attributes.UsageType,
+						ServiceRegions:       []string{region},
+						ServiceProviderName:  "AWS",
+						CurrencyCode:         "USD",
+						CalculatedPrice:      calculatedPrice,
+						PricePerUnit:         fmt.Sprintf("%.10f USD per %s", calculatedPrice, dimension.Unit),
+						UsageUnit:            dimension.Unit,
+						UsageUnitDescription: dimension.Unit,
+					})
+				}
+			}
+		}
+	}
+
+	if p.ServiceCode == "AmazonEC2" {
+		spotSkus, err := p.FetchSpotSKUs(ctx, region)
+		if err != nil {
+			return nil, fmt.Errorf("FetchSpotSKUs: %w", err)
+		}
+		allSkus = append(allSkus, spotSkus...)
+	}
+
+	return allSkus, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// fetchSpotHistory returns, per instance type, the most recent Linux/
+// UNIX spot price in region. DescribeSpotPriceHistory returns entries
+// newest-first, so the first entry seen per instance type wins.
+func (p *AWSProvider) fetchSpotHistory(ctx context.Context, region string) (map[string]ec2types.SpotPrice, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("cannot load AWS config: %w", err)
+	}
+	client := ec2.NewFromConfig(cfg)
+
+	req := &ec2.DescribeSpotPriceHistoryInput{
+		ProductDescriptions: []string{"Linux/UNIX"},
+	}
+
+	latest := make(map[string]ec2types.SpotPrice)
+	paginator := ec2.NewDescribeSpotPriceHistoryPaginator(client, req)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("DescribeSpotPriceHistory: %w", err)
+		}
+		for _, price := range page.SpotPriceHistory {
+			instanceType := string(price.InstanceType)
+			if _, seen := latest[instanceType]; !seen {
+				latest[instanceType] = price
+			}
+		}
+	}
+
+	return latest, nil
+}
+
+// FetchSpotSKUs implements SpotProvider. It returns one Sku per EC2
+// instance type whose price fields carry the latest spot price (no
+// on-demand price), so callers can re-poll spot prices without
+// re-fetching the full on-demand catalog.
+func (p *AWSProvider) FetchSpotSKUs(ctx context.Context, region string) ([]Sku, error) {
+	if p.ServiceCode != "AmazonEC2" {
+		return nil, fmt.Errorf("spot pricing is only supported for AmazonEC2, got %q", p.ServiceCode)
+	}
+
+	spotHistory, err := p.fetchSpotHistory(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
+	var skus []Sku
+	for instanceType, price := range spotHistory {
+		spotPrice, err := strconv.ParseFloat(aws.ToString(price.SpotPrice), 64)
+		if err != nil {
+			continue
+		}
+		skus = append(skus, Sku{
+			SkuId:               instanceType + "-spot",
+			Description:         fmt.Sprintf("%s Spot", instanceType),
+			ServiceDisplayName:  p.ServiceCode,
+			ResourceFamily:      instanceType,
+			UsageType:           "Spot",
+			ServiceRegions:      []string{region},
+			ServiceProviderName: "AWS",
+			CurrencyCode:        "USD",
+			IsSpot:              true,
+			SpotPrice:           spotPrice,
+			SpotPriceTimestamp:  aws.ToTime(price.Timestamp),
+			CalculatedPrice:     spotPrice,
+			PricePerUnit:        fmt.Sprintf("%.10f USD per Hrs", spotPrice),
+		})
+	}
+
+	return skus, nil
+}
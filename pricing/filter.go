@@ -0,0 +1,143 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FilterOp is the comparison an expression applies to a Sku field,
+// named after the AWS Pricing API's GetProducts filter types.
+type FilterOp string
+
+const (
+	OpTermMatch FilterOp = "TERM_MATCH"
+	OpContains  FilterOp = "CONTAINS"
+	OpAnyOf     FilterOp = "ANY_OF"
+	OpNoneOf    FilterOp = "NONE_OF"
+	OpEquals    FilterOp = "EQUALS"
+)
+
+// filterableFields are the Sku fields a Filter may target.
+var filterableFields = map[string]func(Sku) string{
+	"ResourceFamily":     func(s Sku) string { return s.ResourceFamily },
+	"ResourceGroup":      func(s Sku) string { return s.ResourceGroup },
+	"UsageType":          func(s Sku) string { return s.UsageType },
+	"Description":        func(s Sku) string { return s.Description },
+	"ServiceDisplayName": func(s Sku) string { return s.ServiceDisplayName },
+	"SkuId":              func(s Sku) string { return s.SkuId },
+}
+
+// Filter is a single client-side SKU filter expression, e.g.
+// "Field=ResourceFamily,Type=TERM_MATCH,Value=Compute".
+type Filter struct {
+	Field string
+	Type  FilterOp
+	Value string
+}
+
+// ParseFilter parses a "Field=...,Type=...,Value=..." expression. Field
+// and Type may appear in either order, but Value must be the last key;
+// everything after ",Value=" is taken as the value, so it may itself
+// contain "=" or "," (e.g. a description).
+func ParseFilter(expr string) (Filter, error) {
+	parts := strings.SplitN(expr, ",Value=", 2)
+	if len(parts) != 2 {
+		return Filter{}, fmt.Errorf("invalid filter %q: missing Value", expr)
+	}
+	value := parts[1]
+
+	var f Filter
+	f.Value = value
+	for _, kv := range strings.Split(parts[0], ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return Filter{}, fmt.Errorf("invalid filter %q: malformed key=value pair %q", expr, kv)
+		}
+		switch k {
+		case "Field":
+			f.Field = v
+		case "Type":
+			f.Type = FilterOp(v)
+		default:
+			return Filter{}, fmt.Errorf("invalid filter %q: unknown key %q", expr, k)
+		}
+	}
+
+	if _, ok := filterableFields[f.Field]; !ok {
+		return Filter{}, fmt.Errorf("invalid filter %q: unsupported field %q", expr, f.Field)
+	}
+	switch f.Type {
+	case OpTermMatch, OpContains, OpAnyOf, OpNoneOf, OpEquals:
+	default:
+		return Filter{}, fmt.Errorf("invalid filter %q: unsupported type %q", expr, f.Type)
+	}
+
+	return f, nil
+}
+
+// Matches reports whether sku satisfies the filter.
+func (f Filter) Matches(sku Sku) bool {
+	value := filterableFields[f.Field](sku)
+
+	switch f.Type {
+	case OpTermMatch, OpEquals:
+		return value == f.Value
+	case OpContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(f.Value))
+	case OpAnyOf:
+		for _, candidate := range strings.Split(f.Value, "|") {
+			if value == candidate {
+				return true
+			}
+		}
+		return false
+	case OpNoneOf:
+		for _, candidate := range strings.Split(f.Value, "|") {
+			if value == candidate {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// ApplyFilters returns only the SKUs in skus that satisfy every filter.
+func ApplyFilters(skus []Sku, filters []Filter) []Sku {
+	if len(filters) == 0 {
+		return skus
+	}
+
+	var filtered []Sku
+	for _, sku := range skus {
+		matchesAll := true
+		for _, f := range filters {
+			if !f.Matches(sku) {
+				matchesAll = false
+				break
+			}
+		}
+		if matchesAll {
+			filtered = append(filtered, sku)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	billing "cloud.google.com/go/billing/apiv1"
+	"cloud.google.com/go/billing/apiv1/billingpb"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// gcpServiceIDs are the Cloud Billing Catalog service IDs scanned on
+// every fetch. Kept in one place so adding a new service is a one-line
+// change.
+var gcpServiceIDs = []string{
+	"6F81-5844-456A", // Compute Engine
+	"E505-1604-58F8", // Networking
+	"95FF-2EF5-5EA1", // Cloud Storage
+	"58CD-E7C3-72CA", // Cloud Monitoring
+	"9662-B51E-5089", // Cloud SQL
+	"CCD8-9BF1-090E", // Kubernetes Engine
+	"5490-F7B7-8DF6", // Cloud Logging
+}
+
+// DefaultGCPConcurrency and DefaultGCPQPS are used when GCPProvider is
+// constructed with non-positive values.
+const (
+	DefaultGCPConcurrency = 4
+	DefaultGCPQPS         = 5.0
+)
+
+// GCPProvider fetches SKUs from the Cloud Billing Catalog API. Services
+// are fetched concurrently, up to Concurrency at a time, through a
+// token-bucket limiter that starts at QPS and adapts to the API's
+// observed rate limit (see adaptiveLimiter).
+type GCPProvider struct {
+	APIKey      string
+	Concurrency int
+	QPS         float64
+}
+
+// NewGCPProvider returns a Provider backed by the given Cloud Billing
+// API key. concurrency and qps fall back to DefaultGCPConcurrency and
+// DefaultGCPQPS when non-positive.
+func NewGCPProvider(apiKey string, concurrency int, qps float64) *GCPProvider {
+	if concurrency <= 0 {
+		concurrency = DefaultGCPConcurrency
+	}
+	if qps <= 0 {
+		qps = DefaultGCPQPS
+	}
+	return &GCPProvider{APIKey: apiKey, Concurrency: concurrency, QPS: qps}
+}
+
+// FetchSKUs lists SKUs for every tracked service concurrently and keeps
+// only those applicable to region (or global/multi-region SKUs).
+func (p *GCPProvider) FetchSKUs(ctx context.Context, region string) ([]Sku, error) {
+	c, err := billing.NewCloudCatalogClient(ctx, option.WithAPIKey(p.APIKey))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create Google Cloud Billing client: %w", err)
+	}
+	defer c.Close()
+
+	limiter := newAdaptiveLimiter(p.QPS)
+
+	var mu sync.Mutex
+	var allSkus []Sku
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(p.Concurrency)
+
+	for _, serviceId := range gcpServiceIDs {
+		serviceId := serviceId
+		g.Go(func() error {
+			skus, err := p.fetchServiceSKUs(gCtx, c, limiter, serviceId, region)
+			if err != nil {
+				return fmt.Errorf("service %s: %w", serviceId, err)
+			}
+			mu.Lock()
+			allSkus = append(allSkus, skus...)
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return allSkus, nil
+}
+
+// fetchServiceSKUs lists every SKU for one Cloud Billing Catalog
+// service, applying limiter before each page request and reporting
+// rate-limit responses back to it so it can back off and ramp up.
+func (p *GCPProvider) fetchServiceSKUs(ctx context.Context, c *billing.CloudCatalogClient, limiter *adaptiveLimiter, serviceId, region string) ([]Sku, error) {
+	fmt.Printf("Fetching SKUs for service: %s\n", serviceId)
+	req := &billingpb.ListSkusRequest{
+		Parent: fmt.Sprintf("services/%s", serviceId),
+	}
+	it := c.ListSkus(ctx, req)
+
+	var skus []Sku
+	for {
+		// it.Next() only makes an RPC when the page buffer is empty;
+		// gate the limiter on that boundary rather than on every SKU,
+		// so --concurrency isn't bottlenecked by a shared per-item cap.
+		fetchingPage := it.PageInfo().Remaining() == 0
+		if fetchingPage {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		sku, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			if isResourceExhausted(err) {
+				limiter.backoff()
+				continue
+			}
+			// This is not a fatal error, so we log it and continue.
+			log.Printf("WARN: Error fetching SKU, skipping: %v", err)
+			continue
+		}
+		if fetchingPage {
+			limiter.recordSuccess()
+		}
+
+		if !skuAppliesToRegion(sku, region) {
+			continue
+		}
+
+		var nanos int32
+		var units int64
+		var currencyCode string
+		var calculatedPrice float64
+		var pricePerUnit string
+		var usageUnit, usageUnitDescription string
+		var baseUnit, baseUnitDescription string
+		var baseUnitConversionFactor, displayQuantity float64
+		if len(sku.PricingInfo) > 0 {
+			expr := sku.PricingInfo[0].PricingExpression
+			if len(expr.TieredRates) > 0 {
+				nanos = expr.TieredRates[0].UnitPrice.Nanos
+				units = expr.TieredRates[0].UnitPrice.Units
+				currencyCode = expr.TieredRates[0].UnitPrice.CurrencyCode
+				calculatedPrice = float64(units) + float64(nanos)/1e9
+				pricePerUnit = fmt.Sprintf("%.10f %s per %s", calculatedPrice, currencyCode, expr.UsageUnitDescription)
+			}
+			usageUnit = expr.UsageUnit
+			usageUnitDescription = expr.UsageUnitDescription
+			baseUnit = expr.BaseUnit
+			baseUnitDescription = expr.BaseUnitDescription
+			baseUnitConversionFactor = expr.BaseUnitConversionFactor
+			displayQuantity = expr.DisplayQuantity
+		}
+
+		skus = append(skus, Sku{
+			Name:                     sku.Name,
+			SkuId:                    sku.SkuId,
+			Description:              sku.Description,
+			ServiceDisplayName:       sku.Category.ServiceDisplayName,
+			ResourceFamily:           sku.Category.ResourceFamily,
+			ResourceGroup:            sku.Category.ResourceGroup,
+			UsageType:                sku.Category.UsageType,
+			ServiceRegions:           sku.ServiceRegions,
+			PricingInfo:              sku.PricingInfo,
+			ServiceProviderName:      sku.ServiceProviderName,
+			GeoTaxonomy:              sku.GeoTaxonomy,
+			Nanos:                    nanos,
+			Units:                    units,
+			CurrencyCode:             currencyCode,
+			CalculatedPrice:          calculatedPrice,
+			PricePerUnit:             pricePerUnit,
+			UsageUnit:                usageUnit,
+			UsageUnitDescription:     usageUnitDescription,
+			BaseUnit:                 baseUnit,
+			BaseUnitDescription:      baseUnitDescription,
+			BaseUnitConversionFactor: baseUnitConversionFactor,
+			DisplayQuantity:          displayQuantity,
+			IsSpot:                   sku.Category.UsageType == "Preemptible",
+		})
+	}
+
+	return skus, nil
+}
+
+// FetchSpotSKUs implements SpotProvider. The Cloud Billing Catalog API
+// has no narrower endpoint for preemptible SKUs, so this re-runs the
+// full catalog fetch and keeps only the Preemptible entries, so each
+// call costs as many API requests as FetchSKUs.
+func (p *GCPProvider) FetchSpotSKUs(ctx context.Context, region string) ([]Sku, error) {
+	skus, err := p.FetchSKUs(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
+	spotSkus := make([]Sku, 0, len(skus))
+	for _, sku := range skus {
+		if sku.IsSpot {
+			spotSkus = append(spotSkus, sku)
+		}
+	}
+	return spotSkus, nil
+}
+
+func skuAppliesToRegion(sku *billingpb.Sku, region string) bool {
+	for _, r := range sku.ServiceRegions {
+		if r == region || r == "global" || r == "multi-region" {
+			return true
+		}
+	}
+	return false
+}
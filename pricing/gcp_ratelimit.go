@@ -0,0 +1,104 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rampUpAfterSuccesses is how many consecutive successful requests it
+// takes before adaptiveLimiter ramps its rate back up.
+const rampUpAfterSuccesses = 20
+
+// minQPS is the floor adaptiveLimiter will back off to, so a sustained
+// run of errors can't stall the fetch entirely.
+const minQPS = 0.5
+
+// adaptiveLimiter wraps a token-bucket rate.Limiter that halves its
+// rate on ResourceExhausted/429 responses and ramps back towards the
+// original ceiling after a run of successes.
+type adaptiveLimiter struct {
+	limiter *rate.Limiter
+
+	mu         sync.Mutex
+	ceiling    float64
+	current    float64
+	successRun int
+}
+
+func newAdaptiveLimiter(qps float64) *adaptiveLimiter {
+	return &adaptiveLimiter{
+		limiter: rate.NewLimiter(rate.Limit(qps), 1),
+		ceiling: qps,
+		current: qps,
+	}
+}
+
+// Wait blocks until the limiter permits one more request.
+func (a *adaptiveLimiter) Wait(ctx context.Context) error {
+	return a.limiter.Wait(ctx)
+}
+
+// backoff halves the current rate (down to minQPS) after a rate-limit
+// response, and resets the success streak.
+func (a *adaptiveLimiter) backoff() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.current /= 2
+	if a.current < minQPS {
+		a.current = minQPS
+	}
+	a.successRun = 0
+	a.limiter.SetLimit(rate.Limit(a.current))
+}
+
+// recordSuccess tracks a successful request and ramps the rate back up
+// towards ceiling once rampUpAfterSuccesses have passed without an
+// error.
+func (a *adaptiveLimiter) recordSuccess() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.current >= a.ceiling {
+		return
+	}
+
+	a.successRun++
+	if a.successRun < rampUpAfterSuccesses {
+		return
+	}
+	a.successRun = 0
+
+	a.current *= 1.5
+	if a.current > a.ceiling {
+		a.current = a.ceiling
+	}
+	a.limiter.SetLimit(rate.Limit(a.current))
+}
+
+// isResourceExhausted reports whether err is a gRPC ResourceExhausted
+// status (the Cloud Billing Catalog API's 429 equivalent).
+func isResourceExhausted(err error) bool {
+	return status.Code(err) == codes.ResourceExhausted
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pricing defines the provider-agnostic SKU model and the
+// Provider interface that cloud-specific implementations satisfy.
+package pricing
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/billing/apiv1/billingpb"
+)
+
+// Sku is a provider-agnostic price entry. GCP and AWS providers both
+// normalize their native responses into this shape so downstream JSON
+// output doesn't need to know which cloud it came from.
+type Sku struct {
+	Name                     string
+	SkuId                    string
+	Description              string
+	ServiceDisplayName       string
+	ResourceFamily           string
+	ResourceGroup            string
+	UsageType                string
+	ServiceRegions           []string
+	PricingInfo              []*billingpb.PricingInfo
+	ServiceProviderName      string
+	GeoTaxonomy              *billingpb.GeoTaxonomy
+	Mapping                  string
+	Nanos                    int32
+	Units                    int64
+	CurrencyCode             string
+	UsageUnit                string
+	UsageUnitDescription     string
+	BaseUnit                 string
+	BaseUnitDescription      string
+	BaseUnitConversionFactor float64
+	DisplayQuantity          float64
+	CalculatedPrice          float64
+	PricePerUnit             string
+	IsSpot                   bool
+	SpotPrice                float64
+	SpotPriceTimestamp       time.Time
+}
+
+// Provider fetches SKUs for a single cloud region. Implementations are
+// expected to return normalized Sku values regardless of the shape of
+// the underlying API response.
+type Provider interface {
+	// FetchSKUs returns all SKUs applicable to region.
+	FetchSKUs(ctx context.Context, region string) ([]Sku, error)
+}
+
+// SpotProvider is implemented by providers that can return spot/
+// preemptible SKUs on their own. Callers use this for the
+// --refresh-spot polling loop. The AWS provider queries spot prices
+// only; the GCP provider re-fetches its full catalog on every call and
+// filters it down to the preemptible SKUs.
+type SpotProvider interface {
+	// FetchSpotSKUs returns only the spot/preemptible SKUs for region.
+	FetchSpotSKUs(ctx context.Context, region string) ([]Sku, error)
+}
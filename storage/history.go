@@ -0,0 +1,144 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PricePoint is a single historical price observation for a SKU.
+type PricePoint struct {
+	CurrencyCode string
+	Price        float64
+	FetchedAt    time.Time
+}
+
+// History returns every recorded price observation for skuID, oldest
+// first.
+func (s *Store) History(ctx context.Context, skuID string) ([]PricePoint, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT currency, price, fetched_at
+FROM price_history
+WHERE sku_id = %s
+ORDER BY fetched_at`, s.placeholder(1)), skuID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot query price history for %s: %w", skuID, err)
+	}
+	defer rows.Close()
+
+	var points []PricePoint
+	for rows.Next() {
+		var currency string
+		var price float64
+		var fetchedAt time.Time
+		if err := rows.Scan(&currency, &price, &fetchedAt); err != nil {
+			return nil, fmt.Errorf("cannot scan price history row: %w", err)
+		}
+		points = append(points, PricePoint{
+			CurrencyCode: currency,
+			Price:        price,
+			FetchedAt:    fetchedAt,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// PriceChange describes how a SKU's price moved between the earliest
+// snapshot at or after a cutoff and the latest known snapshot.
+type PriceChange struct {
+	SkuId      string
+	Provider   string
+	Region     string
+	OldPrice   float64
+	NewPrice   float64
+	OldFetched time.Time
+	NewFetched time.Time
+}
+
+// Diff reports SKUs whose price differs between the earliest snapshot
+// taken at or after (now - since) and the latest known snapshot.
+func (s *Store) Diff(ctx context.Context, since time.Duration) ([]PriceChange, error) {
+	cutoff := time.Now().Add(-since)
+
+	rows, err := s.db.QueryContext(ctx, `
+SELECT sku_id, provider, region, price, fetched_at
+FROM price_history
+ORDER BY sku_id, provider, region, fetched_at`)
+	if err != nil {
+		return nil, fmt.Errorf("cannot query price history: %w", err)
+	}
+	defer rows.Close()
+
+	type key struct{ skuID, provider, region string }
+	type point struct {
+		price     float64
+		fetchedAt time.Time
+	}
+	series := make(map[key][]point)
+	order := make([]key, 0)
+
+	for rows.Next() {
+		var k key
+		var price float64
+		var fetchedAt time.Time
+		if err := rows.Scan(&k.skuID, &k.provider, &k.region, &price, &fetchedAt); err != nil {
+			return nil, fmt.Errorf("cannot scan price history row: %w", err)
+		}
+		if _, seen := series[k]; !seen {
+			order = append(order, k)
+		}
+		series[k] = append(series[k], point{price: price, fetchedAt: fetchedAt})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var changes []PriceChange
+	for _, k := range order {
+		points := series[k]
+		latest := points[len(points)-1]
+
+		var earliest *point
+		for i := range points {
+			if !points[i].fetchedAt.Before(cutoff) {
+				earliest = &points[i]
+				break
+			}
+		}
+		if earliest == nil || earliest.price == latest.price {
+			continue
+		}
+
+		changes = append(changes, PriceChange{
+			SkuId:      k.skuID,
+			Provider:   k.provider,
+			Region:     k.region,
+			OldPrice:   earliest.price,
+			NewPrice:   latest.price,
+			OldFetched: earliest.fetchedAt,
+			NewFetched: latest.fetchedAt,
+		})
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2023 Nils Knieling. All Rights Reserved.
+Copyright 2023 Roman Inflianskas. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storage persists fetched SKU snapshots so price changes can
+// be tracked over time, instead of keeping only the most recent
+// timestamped JSON dump.
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/thecloudranger/GC-SKU-Scout-Go/pricing"
+)
+
+// Store persists SKU snapshots and their price history. SQLite is the
+// default backend; Postgres is used when the DSN has a postgres://
+// scheme.
+type Store struct {
+	db     *sql.DB
+	driver string
+}
+
+// Open opens (creating if necessary) a Store for the given database/sql
+// driver ("sqlite3" or "postgres") and data source name.
+func Open(driver, dsn string) (*Store, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s database: %w", driver, err)
+	}
+	s := &Store{db: db, driver: driver}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// OpenFromDSN opens a Store, inferring the driver from the DSN scheme:
+// "postgres://..." or "postgresql://..." selects Postgres, everything
+// else (e.g. "scout.db" or "sqlite://scout.db") selects SQLite.
+func OpenFromDSN(dsn string) (*Store, error) {
+	switch {
+	case strings.HasPrefix(dsn, "postgres://"), strings.HasPrefix(dsn, "postgresql://"):
+		return Open("postgres", dsn)
+	case strings.HasPrefix(dsn, "sqlite://"):
+		return Open("sqlite3", strings.TrimPrefix(dsn, "sqlite://"))
+	default:
+		return Open("sqlite3", dsn)
+	}
+}
+
+// Close closes the underlying database connection.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) migrate() error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS skus (
+			sku_id TEXT NOT NULL,
+			provider TEXT NOT NULL,
+			region TEXT NOT NULL,
+			description TEXT,
+			service_display_name TEXT,
+			resource_family TEXT,
+			resource_group TEXT,
+			usage_type TEXT,
+			PRIMARY KEY (sku_id, provider, region)
+		)`,
+		`CREATE TABLE IF NOT EXISTS price_history (
+			sku_id TEXT NOT NULL,
+			provider TEXT NOT NULL,
+			region TEXT NOT NULL,
+			currency TEXT,
+			units INTEGER,
+			nanos INTEGER,
+			price DOUBLE PRECISION,
+			fetched_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_price_history_sku_region_time
+			ON price_history (sku_id, region, fetched_at)`,
+	}
+	for _, stmt := range statements {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return fmt.Errorf("cannot migrate schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// placeholder returns the positional parameter marker this driver
+// expects for the nth (1-indexed) argument in a query.
+func (s *Store) placeholder(n int) string {
+	if s.driver == "postgres" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// Save upserts each SKU's metadata and appends a price_history row for
+// this fetch. All writes for a snapshot happen in one transaction.
+func (s *Store) Save(ctx context.Context, provider, region string, skus []pricing.Sku, fetchedAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	upsertSku := fmt.Sprintf(`INSERT INTO skus (sku_id, provider, region, description, service_display_name, resource_family, resource_group, usage_type)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (sku_id, provider, region) DO UPDATE SET
+	description = excluded.description,
+	service_display_name = excluded.service_display_name,
+	resource_family = excluded.resource_family,
+	resource_group = excluded.resource_group,
+	usage_type = excluded.usage_type`,
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4),
+		s.placeholder(5), s.placeholder(6), s.placeholder(7), s.placeholder(8))
+
+	insertHistory := fmt.Sprintf(`INSERT INTO price_history (sku_id, provider, region, currency, units, nanos, price, fetched_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)`,
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4),
+		s.placeholder(5), s.placeholder(6), s.placeholder(7), s.placeholder(8))
+
+	for _, sku := range skus {
+		if _, err := tx.ExecContext(ctx, upsertSku, sku.SkuId, provider, region, sku.Description, sku.ServiceDisplayName, sku.ResourceFamily, sku.ResourceGroup, sku.UsageType); err != nil {
+			return fmt.Errorf("cannot upsert sku %s: %w", sku.SkuId, err)
+		}
+		// CalculatedPrice (not units/nanos, which AWS-sourced SKUs leave
+		// at zero) is the authoritative price: it's what every provider
+		// populates regardless of how its native API expresses price.
+		if _, err := tx.ExecContext(ctx, insertHistory, sku.SkuId, provider, region, sku.CurrencyCode, sku.Units, sku.Nanos, sku.CalculatedPrice, fetchedAt); err != nil {
+			return fmt.Errorf("cannot insert price history for %s: %w", sku.SkuId, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("cannot commit snapshot: %w", err)
+	}
+	return nil
+}